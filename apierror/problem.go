@@ -0,0 +1,21 @@
+package apierror
+
+// Problem is an RFC 7807 (application/problem+json) document.
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// ValidationProblem renders a ValidationError as a Problem with HTTP 400.
+func ValidationProblem(verr *ValidationError) Problem {
+	return Problem{
+		Type:   "about:blank#validation-error",
+		Title:  "Receipt validation failed",
+		Status: 400,
+		Detail: verr.Error(),
+		Errors: verr.Errors,
+	}
+}
@@ -0,0 +1,37 @@
+// Package apierror carries structured validation failures through to the
+// HTTP layer, which renders them as RFC 7807 problem+json documents.
+package apierror
+
+import "strings"
+
+// FieldError describes one failed validation on a single field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError collects every FieldError found while validating a
+// request. A nil *ValidationError means validation passed.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Add appends a FieldError to the set.
+func (e *ValidationError) Add(field, code, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+// HasErrors reports whether any field errors have been added.
+func (e *ValidationError) HasErrors() bool {
+	return e != nil && len(e.Errors) > 0
+}
+
+// Error satisfies the error interface by joining each field's message.
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		messages[i] = fe.Message
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Item is a single line item on a Receipt.
+type Item struct {
+	ShortDescription string `json:"shortDescription"`
+	Price            string `json:"price"`
+}
+
+// Receipt is the request/response body for the receipt endpoints.
+type Receipt struct {
+	Retailer     string `json:"retailer"`
+	PurchaseDate string `json:"purchaseDate"`
+	PurchaseTime string `json:"purchaseTime"`
+	Total        string `json:"total"`
+	Items        []Item `json:"items"`
+}
+
+// ProcessReceiptResponse is the body of a successful POST /receipts/process.
+type ProcessReceiptResponse struct {
+	UUID    string  `json:"uuid"`
+	Receipt Receipt `json:"receipt"`
+}
+
+// ReceiptPointsResponse is the body of GET /receipts/{id}/points.
+type ReceiptPointsResponse struct {
+	Points    int            `json:"points"`
+	Breakdown map[string]int `json:"breakdown,omitempty"`
+}
+
+// FieldError describes one failed validation on a single field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BulkProcessRequest is the body accepted by POST /receipts/process/bulk.
+type BulkProcessRequest struct {
+	Receipts []Receipt `json:"receipts"`
+}
+
+// BulkProcessResult reports the outcome of validating and storing a single
+// receipt from a bulk request, keyed to its position in the input array.
+type BulkProcessResult struct {
+	Index  int          `json:"index"`
+	UUID   string       `json:"uuid,omitempty"`
+	Points int          `json:"points"`
+	Status string       `json:"status"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// BulkProcessResponse is the body of a successful POST /receipts/process/bulk.
+type BulkProcessResponse struct {
+	Results []BulkProcessResult `json:"results"`
+}
+
+// BulkStatusRequest is the body accepted by POST /receipts/status/bulk.
+type BulkStatusRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// BulkStatusResult reports whether a single id from a bulk status request
+// exists, and its points if so.
+type BulkStatusResult struct {
+	ID     string `json:"id"`
+	Exists bool   `json:"exists"`
+	Points int    `json:"points"`
+}
+
+// BulkStatusResponse is the body of a successful POST /receipts/status/bulk.
+type BulkStatusResponse struct {
+	Results []BulkStatusResult `json:"results"`
+}
+
+// ListReceiptsParams binds the optional query params accepted by
+// GET /receipts. Zero-value fields are omitted from the request.
+type ListReceiptsParams struct {
+	Page             int
+	PageSize         int
+	Retailer         string
+	MinTotal         *float64
+	MaxTotal         *float64
+	PurchaseDateFrom string
+	PurchaseDateTo   string
+	SortBy           string
+	SortDir          string
+}
+
+func (p ListReceiptsParams) query() string {
+	q := url.Values{}
+	if p.Page != 0 {
+		q.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PageSize != 0 {
+		q.Set("pageSize", strconv.Itoa(p.PageSize))
+	}
+	if p.Retailer != "" {
+		q.Set("retailer", p.Retailer)
+	}
+	if p.MinTotal != nil {
+		q.Set("minTotal", strconv.FormatFloat(*p.MinTotal, 'f', -1, 64))
+	}
+	if p.MaxTotal != nil {
+		q.Set("maxTotal", strconv.FormatFloat(*p.MaxTotal, 'f', -1, 64))
+	}
+	if p.PurchaseDateFrom != "" {
+		q.Set("purchaseDateFrom", p.PurchaseDateFrom)
+	}
+	if p.PurchaseDateTo != "" {
+		q.Set("purchaseDateTo", p.PurchaseDateTo)
+	}
+	if p.SortBy != "" {
+		q.Set("sortBy", p.SortBy)
+	}
+	if p.SortDir != "" {
+		q.Set("sortDir", p.SortDir)
+	}
+	return q.Encode()
+}
+
+// ReceiptListItem is a Receipt paired with the id it's stored under.
+type ReceiptListItem struct {
+	UUID string `json:"uuid"`
+	Receipt
+}
+
+// ReceiptPage is the response body of a successful GET /receipts.
+type ReceiptPage struct {
+	Items      []ReceiptListItem `json:"items"`
+	TotalCount int               `json:"totalCount"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"pageSize"`
+}
+
+// Client is a typed client for the Receipt Processor API.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client that talks to baseURL using http.DefaultClient.
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, HTTPClient: http.DefaultClient}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body any, out any) error {
+	var reqBody *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// ProcessReceipt submits a single receipt for processing.
+func (c *Client) ProcessReceipt(ctx context.Context, receipt Receipt) (*ProcessReceiptResponse, error) {
+	var out ProcessReceiptResponse
+	if err := c.do(ctx, http.MethodPost, "/receipts/process", receipt, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReceiptPoints fetches the points awarded to the receipt stored as id.
+func (c *Client) GetReceiptPoints(ctx context.Context, id string) (*ReceiptPointsResponse, error) {
+	var out ReceiptPointsResponse
+	if err := c.do(ctx, http.MethodGet, "/receipts/"+id+"/points", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProcessReceiptsBulk submits a batch of receipts for processing. A
+// per-receipt failure is reported in the corresponding BulkProcessResult
+// rather than failing the whole call.
+func (c *Client) ProcessReceiptsBulk(ctx context.Context, receipts []Receipt) (*BulkProcessResponse, error) {
+	var out BulkProcessResponse
+	req := BulkProcessRequest{Receipts: receipts}
+	if err := c.do(ctx, http.MethodPost, "/receipts/process/bulk", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReceiptsStatusBulk reports whether each of ids exists, and its points if
+// so.
+func (c *Client) ReceiptsStatusBulk(ctx context.Context, ids []string) (*BulkStatusResponse, error) {
+	var out BulkStatusResponse
+	req := BulkStatusRequest{IDs: ids}
+	if err := c.do(ctx, http.MethodPost, "/receipts/status/bulk", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListReceipts fetches a filtered, sorted page of stored receipts.
+func (c *Client) ListReceipts(ctx context.Context, params ListReceiptsParams) (*ReceiptPage, error) {
+	var out ReceiptPage
+	path := "/receipts"
+	if q := params.query(); q != "" {
+		path += "?" + q
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,26 @@
+// Command specgen writes the API's OpenAPI 3 document to disk. The running
+// server does not read this file: /openapi.json, which the Swagger UI at
+// /docs loads, is built in memory by openapi.Build.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/phantoml25/receipt-processor-challenge/openapi"
+)
+
+func main() {
+	out := flag.String("o", "openapi.json", "output path")
+	flag.Parse()
+
+	data, err := json.MarshalIndent(openapi.Build(), "", "  ")
+	if err != nil {
+		log.Fatalf("marshaling spec: %v", err)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatalf("writing %s: %v", *out, err)
+	}
+}
@@ -9,148 +9,229 @@ package main
 *	hosted at localhost:8080 for simplicity
 ***********************/
 import (
+	_ "embed"
 	"encoding/json"
 	"errors"
-	"math"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lithammer/shortuuid"
+	"github.com/phantoml25/receipt-processor-challenge/apierror"
+	"github.com/phantoml25/receipt-processor-challenge/metrics"
+	"github.com/phantoml25/receipt-processor-challenge/openapi"
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+	"github.com/phantoml25/receipt-processor-challenge/scoring"
+	"github.com/phantoml25/receipt-processor-challenge/store"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gopkg.in/yaml.v3"
 )
 
-type Receipt struct {
-	Retailer     string `json:"retailer" binding:"required"`
-	PurchaseDate string `json:"purchaseDate"`
-	PurchaseTime string `json:"purchaseTime"`
-	Items        []struct {
-		ShortDescription string `json:"shortDescription"`
-		Price            string `json:"price"`
-	}
-	Total  string `json:"total"`
-	Points int    `json:"-"`
-}
+type Receipt = receipt.Receipt
 
 type URI struct {
 	ID string `uri:"id"`
 }
 
-func ReadReceipt(c *gin.Context) (Receipt, error) {
+// purchaseDatePattern matches yyyy-mm-dd with a real month (01-12) and day
+// (01-31).
+var purchaseDatePattern = regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])$`)
+
+// purchaseTimePattern matches hh:mm with a real 24-hour hour (00-23).
+var purchaseTimePattern = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`)
+
+// ReadReceipt decodes a Receipt from the request body and validates it.
+func ReadReceipt(c *gin.Context, rules *scoring.RuleSet) (Receipt, error) {
 	var receipt Receipt
+	if err := json.NewDecoder(c.Request.Body).Decode(&receipt); err != nil {
+		return receipt, err
+	}
+	return ValidateReceipt(receipt, rules)
+}
+
+// ValidateReceipt checks an already-decoded Receipt and scores it against
+// rules. It is the shared validation/scoring path for both the single and
+// bulk processing endpoints. A non-nil error is always a
+// *apierror.ValidationError.
+func ValidateReceipt(receipt Receipt, rules *scoring.RuleSet) (Receipt, error) {
+	var verr apierror.ValidationError
+
+	if receipt.Retailer == "" {
+		verr.Add("retailer", "missing_retailer", "retailer is required")
+	}
+	if !purchaseDatePattern.MatchString(receipt.PurchaseDate) {
+		verr.Add("purchaseDate", "invalid_date", "purchaseDate must be formatted yyyy-mm-dd")
+	}
+	if !purchaseTimePattern.MatchString(receipt.PurchaseTime) {
+		verr.Add("purchaseTime", "invalid_time", "purchaseTime must be formatted hh:mm (24-hour)")
+	}
+
+	total, _ := strconv.ParseFloat(receipt.Total, 64)
+	for _, item := range receipt.Items {
+		itemPrice, _ := strconv.ParseFloat(item.Price, 64)
+		total -= itemPrice
+	}
+	if total != 0 {
+		verr.Add("total", "total_mismatch", "total does not match the sum of item prices")
+	}
+
+	if verr.HasErrors() {
+		return receipt, &verr
+	}
+
+	points, _ := rules.Apply(receipt)
+	receipt.Points = points
+	return receipt, nil
+}
+
+// writeValidationProblem renders err as application/problem+json. If err is
+// a *apierror.ValidationError its field errors are included; otherwise it's
+// treated as a generic bad request (e.g. a JSON decode failure).
+func writeValidationProblem(c *gin.Context, err error) {
+	var verr *apierror.ValidationError
+	problem := apierror.Problem{
+		Type:   "about:blank#invalid-request",
+		Title:  "Invalid request",
+		Status: 400,
+		Detail: err.Error(),
+	}
+	if errors.As(err, &verr) {
+		problem = apierror.ValidationProblem(verr)
+	}
+
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(problem.Status, problem)
+}
+
+// cliFlags are parsed once in main() and fed to newStore and newRuleSet.
+type cliFlags struct {
+	store       string
+	sqlitePath  string
+	postgresDSN string
+	rulesConfig string
+}
+
+func parseFlags() cliFlags {
+	store := flag.String("store", envOr("STORE_DRIVER", "memory"), "storage backend: memory, sqlite, postgres")
+	sqlitePath := flag.String("sqlite-path", envOr("SQLITE_PATH", "receipts.db"), "path to the sqlite database file (store=sqlite)")
+	postgresDSN := flag.String("postgres-dsn", envOr("POSTGRES_DSN", ""), "postgres connection string (store=postgres)")
+	rulesConfig := flag.String("rules-config", envOr("RULES_CONFIG", ""), "path to a YAML or JSON scoring rules config")
+	flag.Parse()
+	return cliFlags{store: *store, sqlitePath: *sqlitePath, postgresDSN: *postgresDSN, rulesConfig: *rulesConfig}
+}
+
+// newStore builds the ReceiptStore selected by flags.store.
+func newStore(flags cliFlags) (store.ReceiptStore, error) {
+	switch flags.store {
+	case "memory":
+		return store.NewMemoryStore(), nil
+	case "sqlite":
+		return store.NewSQLiteStore(flags.sqlitePath)
+	case "postgres":
+		return store.NewPostgresStore(flags.postgresDSN)
+	default:
+		return nil, errors.New("unknown -store driver: " + flags.store)
+	}
+}
+
+// newRuleSet builds the scoring.RuleSet from flags.rulesConfig, falling
+// back to scoring.DefaultConfig when no path is given.
+func newRuleSet(flags cliFlags) (*scoring.RuleSet, error) {
+	if flags.rulesConfig == "" {
+		return scoring.Build(scoring.DefaultConfig())
+	}
+
+	cfg, err := loadScoringConfig(flags.rulesConfig)
+	if err != nil {
+		return nil, fmt.Errorf("loading rules config %s: %w", flags.rulesConfig, err)
+	}
+	return scoring.Build(cfg)
+}
 
-	err := json.NewDecoder(c.Request.Body).Decode(&receipt)
+func loadScoringConfig(path string) (scoring.Config, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		//Invalid receipt passed
+		return scoring.Config{}, err
+	}
+
+	var cfg scoring.Config
+	if strings.HasSuffix(path, ".json") {
+		err = json.Unmarshal(data, &cfg)
 	} else {
-		err := errors.New("")
-		//Valid Decode, validate Receipt
-		//Retailer is safe
-		//Purchase date must be yyyy-mm-dd
-		purchaseDate := receipt.PurchaseDate
-		isdate, _ := regexp.Compile(`^[0-9]{4}-[0-1][1-9]-[0-2][0-9]`)
-		if !isdate.MatchString(purchaseDate) {
-			dateErr := errors.New("invalid Purchase Date Format ")
-			err = errors.Join(err, dateErr)
-		}
-		//purchase time must be hh:mm
-		purchaseTime := receipt.PurchaseTime
-		istime, _ := regexp.Compile(`^[0-2][0-9]:[0-5][0-9]`)
-		if !istime.MatchString(purchaseTime) {
-			timeErr := errors.New("invalid Purchase Time Format ")
-			err = errors.Join(err, timeErr)
-		}
-		//total must equal item prices sum
-		//perform points check during this loop
-		total, _ := strconv.ParseFloat(receipt.Total, 64)
-		points := 0
-		//50 points if the total is a round dollar amount with no cents.
-		if math.Mod(total, 1) == 0 {
-			points += 50
-		}
-		//25 points if the total is a multiple of `0.25`
-		if math.Mod(total, 0.25) == 0 {
-			points += 25
-		}
-		//One point for every alphanumeric character in the retailer name
-		name := receipt.Retailer
-		chars := strings.Split(name, "")
-		j := 0
-		for j < len(chars) {
-			isalpha, _ := regexp.Compile(`^[a-zA-Z0-9]*$`)
-			if isalpha.MatchString(chars[j]) {
-				points += 1
-			}
-			j++
-		}
-		//5 points for every two items on the receipt.
-		items := len(receipt.Items)
-		points += (items / 2)
-		//Loop through the items
-		i := 0
-		for i < len(receipt.Items) {
-			itemPrice, _ := strconv.ParseFloat(receipt.Items[i].Price, 64)
-			total -= itemPrice
-			//calculate points for this item
-			//If the trimmed length of the item description is a multiple of 3,/
-			//multiply the price by `0.2` and round up to the nearest integer./
-			//The result is the number of points earned.
-			desc := receipt.Items[i].ShortDescription
-			if len(desc)%3 == 0 {
-				price, _ := strconv.ParseFloat(receipt.Items[i].Price, 64)
-				price = price + (1 - math.Mod(price, 1))
-				points += int(price)
-			}
-			//6 points if the day in the purchase date is odd.
-			day := receipt.PurchaseDate[len(receipt.PurchaseDate)-2:]
-			dayint, err := strconv.Atoi(day)
-			if err != nil {
-				//handle
-			}
-			if dayint%2 == 1 {
-				points += 6
-			}
-			//10 points if the time of purchase is after 2:00pm and before 4:00pm.
-			time := receipt.PurchaseTime[:2]
-			timeint, err := strconv.Atoi(time)
-			if err != nil {
-				//handle
-			}
-			if 14 <= timeint && timeint < 16 {
-				points += 10
-			}
-			i++
-		}
-		receipt.Points = points
-		if total != 0 {
-			totalErr := errors.New("total Price does not match item prices ")
-			err = errors.Join(err, totalErr)
-		}
-		if len(err.Error()) == 0 {
-			//if all checks pass, clear err for output
-			err = nil
-		}
+		err = yaml.Unmarshal(data, &cfg)
+	}
+	return cfg, err
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
-	return receipt, err
+	return fallback
 }
 
-var db = make(map[string] /*shortuuid*/ Receipt)
+//go:embed docs.html
+var docsHTML []byte
 
-func setupRouter() *gin.Engine {
+// recordStoreSize refreshes the receipts_in_store gauge. It's cheap enough
+// for the in-memory and embedded-SQL stores this API targets; a store
+// backed by a much larger table would want a COUNT(*) query instead.
+func recordStoreSize(db store.ReceiptStore) {
+	all, err := db.List()
+	if err != nil {
+		return
+	}
+	metrics.ReceiptsInStore.Set(float64(len(all)))
+}
+
+func setupRouter(db store.ReceiptStore, rules *scoring.RuleSet) (*gin.Engine, error) {
 	r := gin.New()
 	r.SetTrustedProxies(nil)
+	r.Use(metrics.RequestLogger(slog.Default()))
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	spec := openapi.Build()
+	validateRequest, err := openapi.ValidateRequestMiddleware(spec)
+	if err != nil {
+		return nil, fmt.Errorf("building request validation middleware: %w", err)
+	}
+	r.Use(validateRequest)
+
+	r.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(200, spec)
+	})
+	r.GET("/docs", func(c *gin.Context) {
+		c.Data(200, "text/html; charset=utf-8", docsHTML)
+	})
+
 	r.POST("/receipts/process", func(c *gin.Context) {
-		receipt, err := ReadReceipt(c)
-		if err == nil {
-			uuid := shortuuid.New()
-
-			db[uuid] = receipt
-			c.JSON(200, gin.H{"uuid": uuid, "receipt": receipt})
-		} else {
-			c.JSON(400, gin.H{"msg": err})
+		receipt, err := ReadReceipt(c, rules)
+		if err != nil {
+			metrics.ReceiptsProcessedTotal.WithLabelValues("invalid").Inc()
+			writeValidationProblem(c, err)
+			return
 		}
+
+		uuid := shortuuid.New()
+		if err := db.Put(uuid, receipt); err != nil {
+			c.JSON(500, gin.H{"msg": err.Error()})
+			return
+		}
+		metrics.ReceiptsProcessedTotal.WithLabelValues("ok").Inc()
+		metrics.ReceiptPointsAwarded.Observe(float64(receipt.Points))
+		recordStoreSize(db)
+		c.JSON(200, gin.H{"uuid": uuid, "receipt": receipt})
 	})
 
+	r.POST("/receipts/process/bulk", bulkProcessHandler(db, rules))
+	r.POST("/receipts/status/bulk", bulkStatusHandler(db))
+
 	r.GET("/receipts/:id/points", func(c *gin.Context) {
 		var uri URI
 		var uuid string
@@ -159,23 +240,66 @@ func setupRouter() *gin.Engine {
 			return
 		}
 		uuid = uri.ID
-		receipt, ok := db[uuid]
-		if !ok {
+		receipt, err := db.Get(uuid)
+		if err != nil {
 			c.JSON(400, gin.H{"msg": "That receipt does not exist.", "uuid": uuid})
 			return
 		}
+		if c.Query("explain") == "1" {
+			total, breakdown := rules.Apply(receipt)
+			c.JSON(200, gin.H{"points": total, "breakdown": breakdown})
+			return
+		}
 		c.JSON(200, gin.H{"points": receipt.Points})
 	})
 
+	r.GET("/receipts", func(c *gin.Context) {
+		var cmd ReceiptPagedRequestCommand
+		if err := c.ShouldBindQuery(&cmd); err != nil {
+			c.JSON(400, gin.H{"msg": err.Error()})
+			return
+		}
+		if err := cmd.Normalize(); err != nil {
+			c.JSON(400, gin.H{"msg": err.Error()})
+			return
+		}
+		all, err := db.List()
+		if err != nil {
+			c.JSON(500, gin.H{"msg": err.Error()})
+			return
+		}
+		c.JSON(200, pageReceipts(all, cmd))
+	})
+
 	r.GET("/db", func(c *gin.Context) {
-		c.JSON(200, gin.H{"database": db})
+		all, err := db.List()
+		if err != nil {
+			c.JSON(500, gin.H{"msg": err.Error()})
+			return
+		}
+		c.JSON(200, gin.H{"database": all})
 	})
-	return r
+	return r, nil
 }
 
 func main() {
 	//gin.SetMode(gin.ReleaseMode) //Disable for debug output
-	r := setupRouter()
+	flags := parseFlags()
+
+	db, err := newStore(flags)
+	if err != nil {
+		log.Fatalf("initializing store: %v", err)
+	}
+
+	rules, err := newRuleSet(flags)
+	if err != nil {
+		log.Fatalf("initializing scoring rules: %v", err)
+	}
+
+	r, err := setupRouter(db, rules)
+	if err != nil {
+		log.Fatalf("setting up router: %v", err)
+	}
 
 	r.Run(":8080")
 }
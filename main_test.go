@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/phantoml25/receipt-processor-challenge/apierror"
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+	"github.com/phantoml25/receipt-processor-challenge/scoring"
+)
+
+func newTestRuleSet(t *testing.T) *scoring.RuleSet {
+	t.Helper()
+	rs, err := scoring.Build(scoring.DefaultConfig())
+	if err != nil {
+		t.Fatalf("building rule set: %v", err)
+	}
+	return rs
+}
+
+// TestValidateReceiptDateRange confirms purchaseDatePattern accepts months
+// 01 and 10-12, including month 10, which an earlier, narrower regex
+// rejected.
+func TestValidateReceiptDateRange(t *testing.T) {
+	rules := newTestRuleSet(t)
+
+	for _, date := range []string{"2022-01-01", "2022-10-01", "2022-11-01", "2022-12-01"} {
+		r := receipt.Receipt{Retailer: "M&M Corner Market", PurchaseDate: date, PurchaseTime: "13:01", Total: "1.00",
+			Items: []receipt.Item{{ShortDescription: "a", Price: "1.00"}}}
+
+		_, err := ValidateReceipt(r, rules)
+		if err != nil {
+			t.Errorf("purchaseDate %q: got error %v, want none", date, err)
+		}
+	}
+}
+
+// TestValidateReceiptTimeRange confirms purchaseTimePattern accepts only
+// 24-hour hours 00-23, rejecting the 24-29 range that an earlier, looser
+// regex let through as valid.
+func TestValidateReceiptTimeRange(t *testing.T) {
+	rules := newTestRuleSet(t)
+	base := receipt.Receipt{Retailer: "M&M Corner Market", PurchaseDate: "2022-01-01", Total: "1.00",
+		Items: []receipt.Item{{ShortDescription: "a", Price: "1.00"}}}
+
+	for _, time := range []string{"00:00", "13:01", "20:00", "23:59"} {
+		r := base
+		r.PurchaseTime = time
+		if _, err := ValidateReceipt(r, rules); err != nil {
+			t.Errorf("purchaseTime %q: got error %v, want none", time, err)
+		}
+	}
+
+	for _, time := range []string{"24:00", "25:30", "99:99"} {
+		r := base
+		r.PurchaseTime = time
+		_, err := ValidateReceipt(r, rules)
+		var verr *apierror.ValidationError
+		if err == nil || !errors.As(err, &verr) || !hasFieldCode(verr, "purchaseTime", "invalid_time") {
+			t.Errorf("purchaseTime %q: got error %v, want a purchaseTime/invalid_time field error", time, err)
+		}
+	}
+}
+
+func hasFieldCode(verr *apierror.ValidationError, field, code string) bool {
+	for _, fe := range verr.Errors {
+		if fe.Field == field && fe.Code == code {
+			return true
+		}
+	}
+	return false
+}
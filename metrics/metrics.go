@@ -0,0 +1,40 @@
+// Package metrics exposes the Prometheus metrics for this service at
+// /metrics, along with the gin middleware that records HTTP metrics and
+// structured request logs.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// ReceiptsProcessedTotal counts receipts processed, labeled by whether
+	// they validated successfully.
+	ReceiptsProcessedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "receipts_processed_total",
+		Help: "Total number of receipts submitted for processing.",
+	}, []string{"status"})
+
+	// ReceiptPointsAwarded tracks the distribution of points awarded per
+	// processed receipt.
+	ReceiptPointsAwarded = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "receipt_points_awarded",
+		Help:    "Points awarded per successfully processed receipt.",
+		Buckets: prometheus.LinearBuckets(0, 25, 10),
+	})
+
+	// HTTPRequestDuration tracks request latency by route, method, and
+	// status code.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "http_request_duration_seconds",
+		Help: "HTTP request latency in seconds.",
+	}, []string{"route", "method", "code"})
+
+	// ReceiptsInStore is the current number of receipts held by the
+	// configured ReceiptStore.
+	ReceiptsInStore = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "receipts_in_store",
+		Help: "Number of receipts currently held by the store.",
+	})
+)
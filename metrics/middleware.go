@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lithammer/shortuuid"
+)
+
+// RequestIDHeader is the header used to propagate a request id end to end:
+// an incoming value is reused, otherwise one is generated and echoed back
+// on the response.
+const RequestIDHeader = "X-Request-Id"
+
+// RequestLogger returns a gin middleware that records HTTP request metrics
+// and emits a structured log line per request via logger.
+func RequestLogger(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = shortuuid.New()
+		}
+		c.Writer.Header().Set(RequestIDHeader, requestID)
+		c.Set("request_id", requestID)
+
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := c.Writer.Status()
+
+		HTTPRequestDuration.WithLabelValues(route, c.Request.Method, strconv.Itoa(status)).Observe(latency.Seconds())
+
+		logger.Info("http_request",
+			"request_id", requestID,
+			"method", c.Request.Method,
+			"route", route,
+			"status", status,
+			"latency_ms", latency.Milliseconds(),
+		)
+	}
+}
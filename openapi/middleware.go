@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	legacyrouter "github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+)
+
+// ValidateRequestMiddleware rejects requests whose body doesn't match doc:
+// a missing body, unknown fields, wrong types, or a bulk body without its
+// required receipts/ids array. Required receipt fields are left to the
+// handlers (see ValidateReceipt) so a single receipt, or one receipt in a
+// bulk batch, can be rejected with field-level detail instead of this
+// middleware failing the whole request first. Routes with no matching
+// operation in doc are passed through unvalidated.
+func ValidateRequestMiddleware(doc *openapi3.T) (gin.HandlerFunc, error) {
+	router, err := legacyrouter.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			// No spec'd operation for this route; nothing to validate against.
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"msg": err.Error()})
+			return
+		}
+		c.Next()
+	}, nil
+}
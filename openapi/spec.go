@@ -0,0 +1,157 @@
+// Package openapi builds the OpenAPI 3.0 document describing this API's
+// routes and request/response bodies, served at /openapi.json and used by
+// the request validation middleware. Package client is written by hand and
+// is not generated from it.
+package openapi
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+func stringSchema() *openapi3.SchemaRef {
+	return openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+}
+
+// itemSchema and receiptSchema intentionally leave Required unset: the spec
+// middleware validates against these schemas ahead of every handler, and the
+// actual required-field checks (with field-level error codes like
+// missing_retailer) belong to ValidateReceipt so it can report them per
+// receipt in a bulk batch instead of rejecting the whole request up front.
+func itemSchema() *openapi3.SchemaRef {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("shortDescription", openapi3.NewStringSchema()).
+		WithProperty("price", openapi3.NewStringSchema()).
+		WithoutAdditionalProperties()
+	return openapi3.NewSchemaRef("", schema)
+}
+
+func receiptSchema() *openapi3.SchemaRef {
+	items := openapi3.NewArraySchema()
+	items.Items = itemSchema()
+
+	schema := openapi3.NewObjectSchema().
+		WithProperty("retailer", openapi3.NewStringSchema()).
+		WithProperty("purchaseDate", openapi3.NewStringSchema()).
+		WithProperty("purchaseTime", openapi3.NewStringSchema()).
+		WithProperty("total", openapi3.NewStringSchema())
+	schema.Properties["items"] = openapi3.NewSchemaRef("", items)
+	schema.WithoutAdditionalProperties()
+	return openapi3.NewSchemaRef("", schema)
+}
+
+func jsonBody(schema *openapi3.SchemaRef) *openapi3.RequestBodyRef {
+	body := openapi3.NewRequestBody().
+		WithRequired(true).
+		WithJSONSchemaRef(schema)
+	return &openapi3.RequestBodyRef{Value: body}
+}
+
+func jsonResponse(description string) *openapi3.ResponseRef {
+	resp := openapi3.NewResponse().WithDescription(description)
+	return &openapi3.ResponseRef{Value: resp}
+}
+
+// Build constructs the OpenAPI document for this API.
+func Build() *openapi3.T {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   "Receipt Processor API",
+			Version: "1.0.0",
+		},
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{
+				"Item":    itemSchema(),
+				"Receipt": receiptSchema(),
+			},
+		},
+		Paths: openapi3.NewPaths(),
+	}
+
+	bulkReceipts := openapi3.NewObjectSchema()
+	items := openapi3.NewArraySchema()
+	items.Items = receiptSchema()
+	bulkReceipts.Properties = openapi3.Schemas{"receipts": openapi3.NewSchemaRef("", items)}
+	bulkReceipts.Required = []string{"receipts"}
+	bulkReceipts.WithoutAdditionalProperties()
+
+	idsArray := openapi3.NewArraySchema()
+	idsArray.Items = stringSchema()
+	bulkIDs := openapi3.NewObjectSchema().WithProperty("ids", idsArray)
+	bulkIDs.Required = []string{"ids"}
+	bulkIDs.WithoutAdditionalProperties()
+
+	doc.Paths.Set("/receipts/process", &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			OperationID: "processReceipt",
+			RequestBody: jsonBody(receiptSchema()),
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, jsonResponse("The stored receipt and its id")),
+				openapi3.WithStatus(400, jsonResponse("Validation failed")),
+			),
+		},
+	})
+
+	doc.Paths.Set("/receipts/process/bulk", &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			OperationID: "processReceiptsBulk",
+			RequestBody: jsonBody(openapi3.NewSchemaRef("", bulkReceipts)),
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, jsonResponse("Per-receipt processing results")),
+			),
+		},
+	})
+
+	doc.Paths.Set("/receipts/status/bulk", &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			OperationID: "receiptStatusBulk",
+			RequestBody: jsonBody(openapi3.NewSchemaRef("", bulkIDs)),
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, jsonResponse("Per-id existence and points")),
+			),
+		},
+	})
+
+	doc.Paths.Set("/receipts/{id}/points", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "getReceiptPoints",
+			Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "id", In: "path", Required: true, Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "explain", In: "query", Schema: stringSchema()}},
+			},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, jsonResponse("The receipt's points")),
+				openapi3.WithStatus(400, jsonResponse("No receipt with that id")),
+			),
+		},
+	})
+
+	doc.Paths.Set("/receipts", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "listReceipts",
+			Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "page", In: "query", Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "pageSize", In: "query", Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "retailer", In: "query", Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "minTotal", In: "query", Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "maxTotal", In: "query", Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "purchaseDateFrom", In: "query", Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "purchaseDateTo", In: "query", Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "sortBy", In: "query", Schema: stringSchema()}},
+				{Value: &openapi3.Parameter{Name: "sortDir", In: "query", Schema: stringSchema()}},
+			},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, jsonResponse("A page of receipts")),
+			),
+		},
+	})
+
+	doc.Paths.Set("/db", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "dumpStore",
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, jsonResponse("Every stored receipt")),
+			),
+		},
+	})
+
+	return doc
+}
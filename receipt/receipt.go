@@ -0,0 +1,19 @@
+// Package receipt holds the domain types shared by the HTTP layer, the
+// scoring engine, and the storage backends.
+package receipt
+
+// Item is a single line item on a Receipt.
+type Item struct {
+	ShortDescription string `json:"shortDescription"`
+	Price            string `json:"price"`
+}
+
+// Receipt is a single submitted receipt along with its computed points.
+type Receipt struct {
+	Retailer     string `json:"retailer" binding:"required"`
+	PurchaseDate string `json:"purchaseDate"`
+	PurchaseTime string `json:"purchaseTime"`
+	Items        []Item `json:"items"`
+	Total        string `json:"total"`
+	Points       int    `json:"-"`
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lithammer/shortuuid"
+	"github.com/phantoml25/receipt-processor-challenge/apierror"
+	"github.com/phantoml25/receipt-processor-challenge/metrics"
+	"github.com/phantoml25/receipt-processor-challenge/scoring"
+	"github.com/phantoml25/receipt-processor-challenge/store"
+)
+
+// bulkProcessRequest is the body accepted by POST /receipts/process/bulk.
+type bulkProcessRequest struct {
+	Receipts []Receipt `json:"receipts"`
+}
+
+// bulkProcessResult reports the outcome of validating and storing a single
+// receipt from a bulk request, keyed to its position in the input array.
+type bulkProcessResult struct {
+	Index  int                   `json:"index"`
+	UUID   string                `json:"uuid,omitempty"`
+	Points int                   `json:"points"`
+	Status string                `json:"status"`
+	Errors []apierror.FieldError `json:"errors,omitempty"`
+}
+
+// bulkStatusRequest is the body accepted by POST /receipts/status/bulk.
+type bulkStatusRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// bulkStatusResult reports whether a single id from a bulk status request
+// exists, and its points if so.
+type bulkStatusResult struct {
+	ID     string `json:"id"`
+	Exists bool   `json:"exists"`
+	Points int    `json:"points"`
+}
+
+func bulkProcessHandler(db store.ReceiptStore, rules *scoring.RuleSet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req bulkProcessRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, gin.H{"msg": err.Error()})
+			return
+		}
+
+		results := make([]bulkProcessResult, len(req.Receipts))
+		for i, r := range req.Receipts {
+			validated, err := ValidateReceipt(r, rules)
+			if err != nil {
+				metrics.ReceiptsProcessedTotal.WithLabelValues("invalid").Inc()
+				var verr *apierror.ValidationError
+				if errors.As(err, &verr) {
+					results[i] = bulkProcessResult{Index: i, Status: "invalid", Errors: verr.Errors}
+				} else {
+					results[i] = bulkProcessResult{Index: i, Status: "invalid", Errors: []apierror.FieldError{{Message: err.Error()}}}
+				}
+				continue
+			}
+			uuid := shortuuid.New()
+			if err := db.Put(uuid, validated); err != nil {
+				results[i] = bulkProcessResult{Index: i, Status: "invalid", Errors: []apierror.FieldError{{Message: err.Error()}}}
+				continue
+			}
+			metrics.ReceiptsProcessedTotal.WithLabelValues("ok").Inc()
+			metrics.ReceiptPointsAwarded.Observe(float64(validated.Points))
+			results[i] = bulkProcessResult{Index: i, UUID: uuid, Points: validated.Points, Status: "ok"}
+		}
+		recordStoreSize(db)
+		c.JSON(200, gin.H{"results": results})
+	}
+}
+
+func bulkStatusHandler(db store.ReceiptStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req bulkStatusRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, gin.H{"msg": err.Error()})
+			return
+		}
+
+		results := make([]bulkStatusResult, len(req.IDs))
+		for i, id := range req.IDs {
+			r, err := db.Get(id)
+			if err != nil {
+				results[i] = bulkStatusResult{ID: id, Exists: false}
+				continue
+			}
+			results[i] = bulkStatusResult{ID: id, Exists: true, Points: r.Points}
+		}
+		c.JSON(200, gin.H{"results": results})
+	}
+}
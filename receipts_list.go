@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+)
+
+// ReceiptFilter holds the compound predicates a receipt must satisfy to be
+// included in a paged listing.
+type ReceiptFilter struct {
+	Retailer         string
+	MinTotal         *float64
+	MaxTotal         *float64
+	PurchaseDateFrom string
+	PurchaseDateTo   string
+}
+
+// ReceiptPagedRequestCommand binds the query params accepted by GET
+// /receipts, validates them, and exposes the resulting ReceiptFilter.
+type ReceiptPagedRequestCommand struct {
+	Page             int      `form:"page"`
+	PageSize         int      `form:"pageSize"`
+	Retailer         string   `form:"retailer"`
+	MinTotal         *float64 `form:"minTotal"`
+	MaxTotal         *float64 `form:"maxTotal"`
+	PurchaseDateFrom string   `form:"purchaseDateFrom"`
+	PurchaseDateTo   string   `form:"purchaseDateTo"`
+	SortBy           string   `form:"sortBy"`
+	SortDir          string   `form:"sortDir"`
+
+	Filter ReceiptFilter `form:"-"`
+}
+
+// Normalize fills in defaults, validates bounds, and populates Filter from
+// the bound query params. It must be called after binding and before the
+// command is used.
+func (cmd *ReceiptPagedRequestCommand) Normalize() error {
+	if cmd.Page <= 0 {
+		cmd.Page = 1
+	}
+	if cmd.PageSize <= 0 {
+		cmd.PageSize = 20
+	}
+	if cmd.PageSize > 100 {
+		return fmt.Errorf("pageSize must be <= 100")
+	}
+
+	switch cmd.SortBy {
+	case "":
+		cmd.SortBy = "purchaseDate"
+	case "points", "total", "purchaseDate":
+	default:
+		return fmt.Errorf("sortBy must be one of points, total, purchaseDate")
+	}
+
+	switch cmd.SortDir {
+	case "":
+		cmd.SortDir = "asc"
+	case "asc", "desc":
+	default:
+		return fmt.Errorf("sortDir must be asc or desc")
+	}
+
+	cmd.Filter = ReceiptFilter{
+		Retailer:         cmd.Retailer,
+		MinTotal:         cmd.MinTotal,
+		MaxTotal:         cmd.MaxTotal,
+		PurchaseDateFrom: cmd.PurchaseDateFrom,
+		PurchaseDateTo:   cmd.PurchaseDateTo,
+	}
+	return nil
+}
+
+// receiptListItem is a Receipt paired with the id it's stored under, which
+// is what a listing needs to actually be useful to a caller.
+type receiptListItem struct {
+	UUID string `json:"uuid"`
+	receipt.Receipt
+}
+
+// ReceiptPage is the response shape for a paged receipt listing.
+type ReceiptPage struct {
+	Items      []receiptListItem `json:"items"`
+	TotalCount int               `json:"totalCount"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"pageSize"`
+}
+
+func matchesFilter(r receipt.Receipt, f ReceiptFilter) bool {
+	if f.Retailer != "" && r.Retailer != f.Retailer {
+		return false
+	}
+	total, _ := strconv.ParseFloat(r.Total, 64)
+	if f.MinTotal != nil && total < *f.MinTotal {
+		return false
+	}
+	if f.MaxTotal != nil && total > *f.MaxTotal {
+		return false
+	}
+	if f.PurchaseDateFrom != "" && r.PurchaseDate < f.PurchaseDateFrom {
+		return false
+	}
+	if f.PurchaseDateTo != "" && r.PurchaseDate > f.PurchaseDateTo {
+		return false
+	}
+	return true
+}
+
+// pageReceipts applies cmd's filter, sort, and pagination to the contents
+// of a store listing.
+func pageReceipts(all map[string]receipt.Receipt, cmd ReceiptPagedRequestCommand) ReceiptPage {
+	matched := make([]receiptListItem, 0, len(all))
+	for uuid, r := range all {
+		if matchesFilter(r, cmd.Filter) {
+			matched = append(matched, receiptListItem{UUID: uuid, Receipt: r})
+		}
+	}
+
+	// less breaks ties on UUID so receipts sharing a sort key get a
+	// deterministic order instead of depending on map iteration order.
+	less := func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		switch cmd.SortBy {
+		case "points":
+			if a.Points != b.Points {
+				return a.Points < b.Points
+			}
+		case "total":
+			at, _ := strconv.ParseFloat(a.Total, 64)
+			bt, _ := strconv.ParseFloat(b.Total, 64)
+			if at != bt {
+				return at < bt
+			}
+		default: // purchaseDate
+			if a.PurchaseDate != b.PurchaseDate {
+				return a.PurchaseDate < b.PurchaseDate
+			}
+		}
+		return a.UUID < b.UUID
+	}
+	if cmd.SortDir == "desc" {
+		sort.SliceStable(matched, func(i, j int) bool { return less(j, i) })
+	} else {
+		sort.SliceStable(matched, func(i, j int) bool { return less(i, j) })
+	}
+
+	totalCount := len(matched)
+	start := (cmd.Page - 1) * cmd.PageSize
+	if start > totalCount {
+		start = totalCount
+	}
+	end := start + cmd.PageSize
+	if end > totalCount {
+		end = totalCount
+	}
+
+	return ReceiptPage{
+		Items:      matched[start:end],
+		TotalCount: totalCount,
+		Page:       cmd.Page,
+		PageSize:   cmd.PageSize,
+	}
+}
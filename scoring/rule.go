@@ -0,0 +1,14 @@
+// Package scoring computes receipt points from a configurable set of
+// rules, replacing the point calculation that used to be inlined in
+// ReadReceipt.
+package scoring
+
+import "github.com/phantoml25/receipt-processor-challenge/receipt"
+
+// Rule is a single, independently named point-scoring rule.
+type Rule interface {
+	// Name identifies the rule in a points breakdown, e.g. "roundDollar".
+	Name() string
+	// Apply returns the points this rule awards for r.
+	Apply(r receipt.Receipt) int
+}
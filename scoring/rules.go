@@ -0,0 +1,143 @@
+package scoring
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+)
+
+var alphanumeric = regexp.MustCompile(`^[a-zA-Z0-9]*$`)
+
+// roundDollarRule awards Points if the total has no cents.
+type roundDollarRule struct {
+	Points int
+}
+
+func (roundDollarRule) Name() string { return "roundDollar" }
+
+func (r roundDollarRule) Apply(receipt receipt.Receipt) int {
+	total, _ := strconv.ParseFloat(receipt.Total, 64)
+	if math.Mod(total, 1) == 0 {
+		return r.Points
+	}
+	return 0
+}
+
+// quarterMultipleRule awards Points if the total is a multiple of Quarter.
+type quarterMultipleRule struct {
+	Points  int
+	Quarter float64
+}
+
+func (quarterMultipleRule) Name() string { return "quarterMultiple" }
+
+func (r quarterMultipleRule) Apply(receipt receipt.Receipt) int {
+	total, _ := strconv.ParseFloat(receipt.Total, 64)
+	if math.Mod(total, r.Quarter) == 0 {
+		return r.Points
+	}
+	return 0
+}
+
+// retailerNameRule awards PointsPerChar for every alphanumeric character in
+// the retailer name.
+type retailerNameRule struct {
+	PointsPerChar int
+}
+
+func (retailerNameRule) Name() string { return "retailerName" }
+
+func (r retailerNameRule) Apply(receipt receipt.Receipt) int {
+	points := 0
+	for _, ch := range strings.Split(receipt.Retailer, "") {
+		if alphanumeric.MatchString(ch) {
+			points += r.PointsPerChar
+		}
+	}
+	return points
+}
+
+// itemPairRule awards PointsPerPair for every two items on the receipt.
+type itemPairRule struct {
+	PointsPerPair int
+}
+
+func (itemPairRule) Name() string { return "itemPair" }
+
+func (r itemPairRule) Apply(receipt receipt.Receipt) int {
+	return (len(receipt.Items) / 2) * r.PointsPerPair
+}
+
+// itemDescriptionRule awards points per item whose trimmed description
+// length is a multiple of DescriptionMultiple: the item price times
+// PriceMultiplier, rounded up to the nearest integer.
+type itemDescriptionRule struct {
+	DescriptionMultiple int
+	PriceMultiplier     float64
+}
+
+func (itemDescriptionRule) Name() string { return "itemDescription" }
+
+func (r itemDescriptionRule) Apply(rcpt receipt.Receipt) int {
+	points := 0
+	for _, item := range rcpt.Items {
+		desc := strings.TrimSpace(item.ShortDescription)
+		if len(desc)%r.DescriptionMultiple != 0 {
+			continue
+		}
+		price, _ := strconv.ParseFloat(item.Price, 64)
+		points += int(math.Ceil(price * r.PriceMultiplier))
+	}
+	return points
+}
+
+// oddDayRule awards Points if the day in the purchase date is odd. Unlike
+// the original implementation, this runs once per receipt, not once per
+// item.
+type oddDayRule struct {
+	Points int
+}
+
+func (oddDayRule) Name() string { return "oddDay" }
+
+func (r oddDayRule) Apply(receipt receipt.Receipt) int {
+	if len(receipt.PurchaseDate) < 2 {
+		return 0
+	}
+	day, err := strconv.Atoi(receipt.PurchaseDate[len(receipt.PurchaseDate)-2:])
+	if err != nil {
+		return 0
+	}
+	if day%2 == 1 {
+		return r.Points
+	}
+	return 0
+}
+
+// afternoonRule awards Points if the purchase time falls within
+// [StartHour, EndHour). Unlike the original implementation, this runs once
+// per receipt, not once per item.
+type afternoonRule struct {
+	Points    int
+	StartHour int
+	EndHour   int
+}
+
+func (afternoonRule) Name() string { return "afternoon" }
+
+func (r afternoonRule) Apply(receipt receipt.Receipt) int {
+	if len(receipt.PurchaseTime) < 2 {
+		return 0
+	}
+	hour, err := strconv.Atoi(receipt.PurchaseTime[:2])
+	if err != nil {
+		return 0
+	}
+	if hour >= r.StartHour && hour < r.EndHour {
+		return r.Points
+	}
+	return 0
+}
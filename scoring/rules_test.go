@@ -0,0 +1,29 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+)
+
+// TestItemDescriptionRuleDefault pins the default itemDescription scoring:
+// ceil(price*0.2) points for items whose trimmed description length is a
+// multiple of 3, so a change here is a deliberate scoring change and not an
+// accident.
+func TestItemDescriptionRuleDefault(t *testing.T) {
+	rule := itemDescriptionRule{DescriptionMultiple: 3, PriceMultiplier: 0.2}
+
+	r := receipt.Receipt{
+		Items: []receipt.Item{
+			{ShortDescription: "Emils Cheese Pizza", Price: "12.25"}, // len 18, multiple of 3
+			{ShortDescription: "  abcdef  ", Price: "2.25"},          // len 6 after trim, multiple of 3
+			{ShortDescription: "Knorr Creamy Chicken", Price: "1.26"}, // len 20, not a multiple of 3
+		},
+	}
+
+	got := rule.Apply(r)
+	want := 3 + 1 // ceil(12.25*0.2)=3, ceil(2.25*0.2)=1, third item scores 0
+	if got != want {
+		t.Errorf("Apply() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package scoring
+
+import "github.com/phantoml25/receipt-processor-challenge/receipt"
+
+// RuleSet composes the configured rules and the per-retailer overrides
+// built from a Config.
+type RuleSet struct {
+	rules         []Rule
+	retailerRules map[string][]Rule
+}
+
+// Apply runs the rules applicable to r (its retailer's override list, if
+// any, otherwise the default list) and returns the total points alongside
+// a breakdown of how each rule contributed.
+func (rs *RuleSet) Apply(r receipt.Receipt) (total int, breakdown map[string]int) {
+	rules := rs.rules
+	if override, ok := rs.retailerRules[r.Retailer]; ok {
+		rules = override
+	}
+
+	breakdown = make(map[string]int, len(rules))
+	for _, rule := range rules {
+		points := rule.Apply(r)
+		breakdown[rule.Name()] += points
+		total += points
+	}
+	return total, breakdown
+}
@@ -0,0 +1,37 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+)
+
+// TestOddDayAndAfternoonAppliedOnce confirms oddDay and afternoon award
+// their points once per receipt, not once per item: a three-item receipt
+// should score the same as a one-item receipt with the same date and time.
+func TestOddDayAndAfternoonAppliedOnce(t *testing.T) {
+	rs := &RuleSet{rules: []Rule{
+		oddDayRule{Points: 6},
+		afternoonRule{Points: 10, StartHour: 14, EndHour: 16},
+	}}
+
+	base := receipt.Receipt{PurchaseDate: "2022-01-01", PurchaseTime: "14:30"}
+	oneItem := base
+	oneItem.Items = []receipt.Item{{ShortDescription: "a", Price: "1.00"}}
+	threeItems := base
+	threeItems.Items = []receipt.Item{
+		{ShortDescription: "a", Price: "1.00"},
+		{ShortDescription: "b", Price: "1.00"},
+		{ShortDescription: "c", Price: "1.00"},
+	}
+
+	onePoints, _ := rs.Apply(oneItem)
+	threePoints, _ := rs.Apply(threeItems)
+
+	if onePoints != 16 {
+		t.Fatalf("one-item receipt: got %d points, want 16", onePoints)
+	}
+	if threePoints != onePoints {
+		t.Fatalf("three-item receipt: got %d points, want %d (same as one item)", threePoints, onePoints)
+	}
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+)
+
+// MemoryStore is a ReceiptStore backed by a map. It does not persist across
+// restarts and is intended for local development and tests.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data map[string]receipt.Receipt
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string]receipt.Receipt)}
+}
+
+func (s *MemoryStore) Put(id string, r receipt.Receipt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[id] = r
+	return nil
+}
+
+func (s *MemoryStore) Get(id string) (receipt.Receipt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r, ok := s.data[id]
+	if !ok {
+		return receipt.Receipt{}, ErrNotFound
+	}
+	return r, nil
+}
+
+func (s *MemoryStore) List() (map[string]receipt.Receipt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]receipt.Receipt, len(s.data))
+	for id, r := range s.data {
+		out[id] = r
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, id)
+	return nil
+}
@@ -0,0 +1,26 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// PostgresStore is a ReceiptStore backed by a Postgres database.
+type PostgresStore struct {
+	sqlStore
+}
+
+// NewPostgresStore connects to the Postgres database identified by dsn and
+// runs migrations against it.
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening postgres store: %w", err)
+	}
+	if err := migrate(db); err != nil {
+		return nil, fmt.Errorf("migrating postgres store: %w", err)
+	}
+	return &PostgresStore{sqlStore{db: db}}, nil
+}
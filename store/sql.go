@@ -0,0 +1,134 @@
+package store
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// sqlRow is the flattened, JSON-serialisable shape a Receipt is stored as.
+type sqlRow struct {
+	ID           string `db:"id"`
+	Retailer     string `db:"retailer"`
+	PurchaseDate string `db:"purchase_date"`
+	PurchaseTime string `db:"purchase_time"`
+	Total        string `db:"total"`
+	Points       int    `db:"points"`
+	Items        string `db:"items"`
+}
+
+// sqlStore is a ReceiptStore backed by a sqlx.DB. Its upsert in Put uses
+// ON CONFLICT ... DO UPDATE, so it works against SQLite and Postgres;
+// SQLiteStore and PostgresStore just choose the driver and DSN.
+type sqlStore struct {
+	db *sqlx.DB
+}
+
+// migrate runs every embedded migration in lexical order. Migrations are
+// additive (CREATE TABLE IF NOT EXISTS) so this is safe to run on every
+// startup.
+func migrate(db *sqlx.DB) error {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		sql, err := migrations.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(string(sql)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toRow(id string, r receipt.Receipt) (sqlRow, error) {
+	items, err := json.Marshal(r.Items)
+	if err != nil {
+		return sqlRow{}, err
+	}
+	return sqlRow{
+		ID:           id,
+		Retailer:     r.Retailer,
+		PurchaseDate: r.PurchaseDate,
+		PurchaseTime: r.PurchaseTime,
+		Total:        r.Total,
+		Points:       r.Points,
+		Items:        string(items),
+	}, nil
+}
+
+func fromRow(row sqlRow) (receipt.Receipt, error) {
+	var r receipt.Receipt
+	if err := json.Unmarshal([]byte(row.Items), &r.Items); err != nil {
+		return receipt.Receipt{}, err
+	}
+	r.Retailer = row.Retailer
+	r.PurchaseDate = row.PurchaseDate
+	r.PurchaseTime = row.PurchaseTime
+	r.Total = row.Total
+	r.Points = row.Points
+	return r, nil
+}
+
+func (s *sqlStore) Put(id string, r receipt.Receipt) error {
+	row, err := toRow(id, r)
+	if err != nil {
+		return err
+	}
+	query := s.db.Rebind(`
+		INSERT INTO receipts (id, retailer, purchase_date, purchase_time, total, points, items)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			retailer = excluded.retailer,
+			purchase_date = excluded.purchase_date,
+			purchase_time = excluded.purchase_time,
+			total = excluded.total,
+			points = excluded.points,
+			items = excluded.items
+	`)
+	_, err = s.db.Exec(query, row.ID, row.Retailer, row.PurchaseDate, row.PurchaseTime, row.Total, row.Points, row.Items)
+	return err
+}
+
+func (s *sqlStore) Get(id string) (receipt.Receipt, error) {
+	var row sqlRow
+	query := s.db.Rebind(`SELECT id, retailer, purchase_date, purchase_time, total, points, items FROM receipts WHERE id = ?`)
+	if err := s.db.Get(&row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return receipt.Receipt{}, ErrNotFound
+		}
+		return receipt.Receipt{}, err
+	}
+	return fromRow(row)
+}
+
+func (s *sqlStore) List() (map[string]receipt.Receipt, error) {
+	var rows []sqlRow
+	if err := s.db.Select(&rows, `SELECT id, retailer, purchase_date, purchase_time, total, points, items FROM receipts`); err != nil {
+		return nil, err
+	}
+	out := make(map[string]receipt.Receipt, len(rows))
+	for _, row := range rows {
+		r, err := fromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		out[row.ID] = r
+	}
+	return out, nil
+}
+
+func (s *sqlStore) Delete(id string) error {
+	query := s.db.Rebind(`DELETE FROM receipts WHERE id = ?`)
+	_, err := s.db.Exec(query, id)
+	return err
+}
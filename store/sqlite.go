@@ -0,0 +1,26 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteStore is a ReceiptStore backed by a SQLite database file.
+type SQLiteStore struct {
+	sqlStore
+}
+
+// NewSQLiteStore opens (creating if necessary) the SQLite database at path
+// and runs migrations against it.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sqlx.Connect("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite store: %w", err)
+	}
+	if err := migrate(db); err != nil {
+		return nil, fmt.Errorf("migrating sqlite store: %w", err)
+	}
+	return &SQLiteStore{sqlStore{db: db}}, nil
+}
@@ -0,0 +1,26 @@
+// Package store defines the ReceiptStore abstraction used by the HTTP
+// handlers and provides in-memory, SQLite, and Postgres implementations.
+package store
+
+import (
+	"errors"
+
+	"github.com/phantoml25/receipt-processor-challenge/receipt"
+)
+
+// ErrNotFound is returned by Get when no receipt exists for the given id.
+var ErrNotFound = errors.New("receipt not found")
+
+// ReceiptStore is the persistence contract for receipts. Implementations
+// must be safe for concurrent use.
+type ReceiptStore interface {
+	// Put saves the receipt under id, overwriting any existing entry.
+	Put(id string, r receipt.Receipt) error
+	// Get returns the receipt stored under id, or ErrNotFound.
+	Get(id string) (receipt.Receipt, error)
+	// List returns every stored receipt keyed by id.
+	List() (map[string]receipt.Receipt, error)
+	// Delete removes the receipt stored under id. It is a no-op if the id
+	// does not exist.
+	Delete(id string) error
+}